@@ -0,0 +1,118 @@
+/*
+
+	auth.go - Authentication helpers for the Todo API.
+
+	This file provides password hashing, JWT issuing/parsing, and the
+	AuthRequired middleware that protects the todo routes. A successfully
+	authenticated request carries the user's ID in its context, retrievable
+	with UserID(ctx).
+
+*/
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/vertionn/Todo-API/httperr"
+)
+
+// ErrInvalidToken is returned by ParseToken when the token is missing,
+// malformed, expired, or signed with the wrong secret.
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// contextKey is an unexported type so values set by this package can't
+// collide with context keys set elsewhere.
+type contextKey int
+
+const userIDKey contextKey = 0
+
+// claims is the JWT payload issued by NewToken.
+type claims struct {
+	UserID int `json:"uid"`
+	jwt.RegisteredClaims
+}
+
+// HashPassword bcrypt-hashes a plaintext password for storage.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the given bcrypt hash.
+func CheckPassword(hash, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
+
+// DummyHash is a bcrypt hash of no real password. Callers comparing login
+// credentials against it when a user isn't found pay the same bcrypt cost
+// as a real comparison, so "unknown email" and "wrong password" take the
+// same time and can't be distinguished by a timing side-channel.
+const DummyHash = "$2a$10$riQQdrKDqgkDPgSBXMbhaOvx6BjmwtFjKyDRqNvV84kAhlbtufh.q"
+
+// NewToken issues a signed JWT for userID that expires after ttl.
+func NewToken(secret []byte, userID int, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+	return token.SignedString(secret)
+}
+
+// ParseToken validates tokenString and returns the user ID it carries. The
+// accepted signing method is pinned to HS256 so a token can't pick its own
+// alg (e.g. "none") and trick the keyfunc into validating against a secret
+// it was never meant to be checked with.
+func ParseToken(secret []byte, tokenString string) (int, error) {
+	var c claims
+	token, err := jwt.ParseWithClaims(tokenString, &c, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return 0, ErrInvalidToken
+	}
+	return c.UserID, nil
+}
+
+// AuthRequired returns middleware that validates the bearer JWT on every
+// request, rejecting the request with 401 if it's missing or invalid, and
+// otherwise injecting the user ID into the request context.
+func AuthRequired(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			tokenString, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || tokenString == "" {
+				httperr.ErrUnauthorized(ErrInvalidToken.Error()).Render(w, r)
+				return
+			}
+
+			userID, err := ParseToken(secret, tokenString)
+			if err != nil {
+				httperr.ErrUnauthorized(ErrInvalidToken.Error()).Render(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserID returns the user ID injected into ctx by AuthRequired.
+func UserID(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(userIDKey).(int)
+	return id, ok
+}
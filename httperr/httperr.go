@@ -0,0 +1,142 @@
+/*
+
+	httperr.go - Structured HTTP error responses for the Todo API.
+
+	ErrResponse is a chi-style error renderer: handlers build one with the
+	constructors below and call Render to send a consistent, machine-readable
+	error payload instead of ad-hoc JSON. Code is optional and lets clients
+	switch on a stable string (e.g. "todo_not_found") rather than parsing
+	ErrorText.
+
+*/
+package httperr
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// ErrResponse represents an error returned to API clients.
+type ErrResponse struct {
+	Err            error  `json:"-"`
+	HTTPStatusCode int    `json:"-"`
+	StatusText     string `json:"status"`
+	ErrorText      string `json:"error,omitempty"`
+	Code           string `json:"code,omitempty"`
+
+	// ValidationErrors lists the fields that failed validation, if any. Only
+	// set by ErrValidation.
+	ValidationErrors []ValidationError `json:"validation_errors,omitempty"`
+}
+
+// ValidationError is a single machine-readable validation failure.
+type ValidationError struct {
+	// Field is the struct field that failed validation, e.g. "Title".
+	Field string `json:"field"`
+
+	// Rule is the validator tag that failed, e.g. "required" or "max".
+	Rule string `json:"rule"`
+
+	// Message is a human-readable description of the failure.
+	Message string `json:"message"`
+}
+
+// Render writes e to w as JSON with the appropriate Content-Type and status
+// code. If e.Err is set, it's logged first since it never reaches the
+// client in the response body.
+func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	if e.Err != nil {
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, e.Err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.HTTPStatusCode)
+	return json.NewEncoder(w).Encode(e)
+}
+
+// ErrBadRequest wraps err as a 400 with no machine-readable code.
+func ErrBadRequest(err error) *ErrResponse {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusBadRequest,
+		StatusText:     "Bad Request",
+		ErrorText:      err.Error(),
+	}
+}
+
+// ErrDecodeFailed is a 400 for a request body that failed to decode.
+func ErrDecodeFailed(err error) *ErrResponse {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusBadRequest,
+		StatusText:     "Bad Request",
+		ErrorText:      "Invalid request data. Please ensure your request is properly formatted.",
+		Code:           "decode_failed",
+	}
+}
+
+// ErrInvalidTodoID is a 400 for a todo ID path segment that isn't an int.
+func ErrInvalidTodoID(err error) *ErrResponse {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusBadRequest,
+		StatusText:     "Bad Request",
+		ErrorText:      "There was a problem with the todo id, please fix it then try again.",
+		Code:           "invalid_todo_id",
+	}
+}
+
+// ErrUnauthorized is a 401 for a missing or invalid bearer token.
+func ErrUnauthorized(msg string) *ErrResponse {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusUnauthorized,
+		StatusText:     "Unauthorized",
+		ErrorText:      msg,
+		Code:           "unauthorized",
+	}
+}
+
+// ErrForbidden is a 403 for a request to a resource the caller doesn't own.
+func ErrForbidden(msg string) *ErrResponse {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusForbidden,
+		StatusText:     "Forbidden",
+		ErrorText:      msg,
+		Code:           "forbidden",
+	}
+}
+
+// ErrNotFound is a 404 with the "todo_not_found" machine-readable code.
+func ErrNotFound(msg string) *ErrResponse {
+	return &ErrResponse{
+		HTTPStatusCode: http.StatusNotFound,
+		StatusText:     "Resource Not Found",
+		ErrorText:      msg,
+		Code:           "todo_not_found",
+	}
+}
+
+// ErrValidation is a 422 for a request body that failed field validation.
+// errs is the translated, per-field detail clients use to highlight the
+// offending inputs.
+func ErrValidation(errs []ValidationError) *ErrResponse {
+	return &ErrResponse{
+		HTTPStatusCode:   http.StatusUnprocessableEntity,
+		StatusText:       "Unprocessable Entity",
+		ErrorText:        "Validation failed.",
+		Code:             "validation_failed",
+		ValidationErrors: errs,
+	}
+}
+
+// ErrInternal wraps err as a 500. The underlying error is never sent to the
+// client, only logged via Err.
+func ErrInternal(err error) *ErrResponse {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusInternalServerError,
+		StatusText:     "Internal Server Error",
+		ErrorText:      "Something went wrong. Please try again later.",
+	}
+}
@@ -20,6 +20,8 @@ package main
 import (
 	jsoniter "github.com/json-iterator/go"
 	"net/http"
+
+	"github.com/vertionn/Todo-API/store"
 )
 
 // NewJson is a jsoniter configuration compatible with the standard library.
@@ -34,11 +36,19 @@ type JsonResponse struct {
 	// Message is an optional message for success.
 	Message string `json:"message,omitempty"`
 
-	// ErrorMessage is an optional error message for failure.
-	ErrorMessage string `json:"error_message,omitempty"`
-
 	// Todos is an optional todo array to show all todos.
-	Todos []TodoStruct `json:"todos,omitempty"`
+	Todos []store.Todo `json:"todos,omitempty"`
+
+	// Total is the number of todos matching the query, before limit/offset
+	// are applied. Pointer so a legitimate 0 still serializes on paginated
+	// responses instead of being dropped like an unset field.
+	Total *int `json:"total,omitempty"`
+
+	// Limit is the page size applied to a paginated response.
+	Limit *int `json:"limit,omitempty"`
+
+	// Offset is the number of matching todos skipped in a paginated response.
+	Offset *int `json:"offset,omitempty"`
 }
 
 // ReturnJSON encodes and sends a JSON response using the 'JsonResponse' structure.
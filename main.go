@@ -2,16 +2,25 @@
 
 	 main.go - This is the main file that holds the logic for the Todo API.
 
-	 It defines the TodoStruct type representing individual todos, and the main router using the
-	 go-chi framework. Endpoints for retrieving, creating, updating, and completing todos are implemented.
-	 The application uses an in-memory slice 'Todos' to store todo items.
+	 It defines the main router using the go-chi framework, backed by a
+	 store.Store for persistence. Every todo route requires a bearer JWT
+	 (see auth.AuthRequired) and is scoped to the authenticated user. Errors
+	 are rendered through httperr.ErrResponse for a consistent contract.
 
 	 API Endpoints:
-	 - GET /todos: Retrieve all todos or a message if none exist.
-	 - POST /create/todo: Create a new todo.
-	 - PUT /update/todo/{ID}: Update a todo by ID.
-	 - PATCH /complete/{ID}: Mark a todo as complete by ID.
-	 - DELETE "/delete/{ID}: Delete a todo a ID
+	 - POST /auth/register: Register a new account.
+	 - POST /auth/login: Exchange credentials for a bearer token.
+	 - GET /todos: Retrieve the caller's todos, filtered/sorted/paginated via
+	   ?status=, ?q=, ?limit=, ?offset=, and ?sort=.
+	 - GET /todos/complete: Shortcut for GET /todos?status=complete.
+	 - POST /create/todo: Create a new todo owned by the caller.
+	 - PUT /update/todo/{ID}: Full-replace a todo owned by the caller; Title
+	   is required, Description/Complete default to their zero value if
+	   omitted.
+	 - PATCH /todos/{ID}: Partially update a todo owned by the caller; only
+	   the fields present in the request body change.
+	 - PATCH /complete/{ID}: Mark a todo owned by the caller as complete.
+	 - DELETE "/delete/{ID}: Delete a todo owned by the caller.
 
 	Author: Nathan
 	Date: 24/11/23
@@ -22,293 +31,529 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/vertionn/Todo-API/auth"
+	"github.com/vertionn/Todo-API/ent"
+	"github.com/vertionn/Todo-API/httperr"
+	"github.com/vertionn/Todo-API/store"
 )
 
-type TodoStruct struct {
-	ID          int
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Complete    bool
+// tokenTTL is how long an issued JWT remains valid.
+const tokenTTL = 24 * time.Hour
+
+// newStore builds the Store and UserStore backends selected by the
+// '-storage' flag (or the STORAGE env var when the flag isn't set):
+// "memory" (the default) or "ent", which persists to SQLite through ent.
+// The DSN for the "ent" backend is read from the DATABASE_URL env var.
+// Only the sqlite3 driver is wired up today; swapping in another ent
+// dialect (e.g. Postgres) means adding its driver import and a DSN-based
+// switch here.
+func newStore(ctx context.Context, backend string) (store.Store, store.UserStore, error) {
+	switch backend {
+	case "", "memory":
+		return store.NewMemoryStore(), store.NewMemoryUserStore(), nil
+	case "ent":
+		dsn := os.Getenv("DATABASE_URL")
+		if dsn == "" {
+			dsn = "file:todo.db?_fk=1"
+		}
+
+		db, err := sql.Open("sqlite3", dsn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening database: %w", err)
+		}
+
+		client := ent.NewClient(ent.Driver(entsql.OpenDB("sqlite3", db)))
+		todoStore, err := store.NewEntStore(ctx, client)
+		if err != nil {
+			return nil, nil, err
+		}
+		return todoStore, store.NewEntUserStore(client), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
 }
 
-func main() {
-	var Todos []TodoStruct
+// listQuery is the parsed, validated form of GET /todos's query parameters.
+type listQuery struct {
+	status string
+	q      string
+	limit  int
+	offset int
+	sort   string
+}
 
-	// r is a new chi router that will handle the HTTP routes.
-	r := chi.NewRouter()
+// parseListQuery parses and validates ?status=, ?q=, ?limit=, ?offset=, and
+// ?sort= for GET /todos. forcedStatus overrides ?status= when non-empty,
+// used by the GET /todos/complete shortcut.
+func parseListQuery(r *http.Request, forcedStatus string) (listQuery, error) {
+	lq := listQuery{status: "all", limit: -1, sort: "id"}
+
+	if forcedStatus != "" {
+		lq.status = forcedStatus
+	} else if v := r.URL.Query().Get("status"); v != "" {
+		switch v {
+		case "complete", "pending", "all":
+			lq.status = v
+		default:
+			return listQuery{}, fmt.Errorf("status must be one of complete, pending, all")
+		}
+	}
 
-	// Use the Chi middleware Logger to log details about each incoming HTTP request.
-	// This middleware captures and logs the request information, such as HTTP method, path, and duration.
-	// It helps in debugging and monitoring the server's behavior without breaking the server itself.
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.Logger)
+	lq.q = r.URL.Query().Get("q")
 
-	r.Get("/todos", func(w http.ResponseWriter, r *http.Request) {
-		switch len(Todos) {
-		case 0:
-			// If there are no Todos, return a message
-			err := ReturnJSON(w, http.StatusOK, JsonResponse{
-				Success: true,
-				Message: "You have no todos. Try adding one.",
-			})
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return listQuery{}, fmt.Errorf("limit must be a non-negative integer")
+		}
+		lq.limit = limit
+	}
 
-			// If there is an error with encoding and sending the JSON back to the client, return the error
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
+	if v := r.URL.Query().Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return listQuery{}, fmt.Errorf("offset must be a non-negative integer")
+		}
+		lq.offset = offset
+	}
+
+	if v := r.URL.Query().Get("sort"); v != "" {
+		switch v {
+		case "id", "-id", "title", "-title":
+			lq.sort = v
 		default:
-			// If there are Todos, return them as JSON
+			return listQuery{}, fmt.Errorf("sort must be one of id, -id, title, -title")
+		}
+	}
+
+	return lq, nil
+}
+
+// applyListQuery filters and sorts todos per lq, then slices out the page
+// selected by limit/offset. total is the match count before pagination.
+func applyListQuery(todos []store.Todo, lq listQuery) (page []store.Todo, total int) {
+	filtered := make([]store.Todo, 0, len(todos))
+	for _, t := range todos {
+		if lq.status == "complete" && !t.Complete {
+			continue
+		}
+		if lq.status == "pending" && t.Complete {
+			continue
+		}
+		if lq.q != "" && !strings.Contains(strings.ToLower(t.Title), strings.ToLower(lq.q)) {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		switch lq.sort {
+		case "-id":
+			return filtered[i].ID > filtered[j].ID
+		case "title":
+			return filtered[i].Title < filtered[j].Title
+		case "-title":
+			return filtered[i].Title > filtered[j].Title
+		default:
+			return filtered[i].ID < filtered[j].ID
+		}
+	})
+
+	total = len(filtered)
+	start := lq.offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if lq.limit >= 0 && start+lq.limit < end {
+		end = start + lq.limit
+	}
+	return filtered[start:end], total
+}
+
+// listTodosHandler returns the GET /todos handler. forcedStatus pins the
+// status filter (used by the GET /todos/complete shortcut); pass "" to let
+// the caller's ?status= query param decide.
+func listTodosHandler(Todos store.Store, forcedStatus string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ownerID, _ := auth.UserID(r.Context())
+
+		lq, err := parseListQuery(r, forcedStatus)
+		if err != nil {
+			httperr.ErrBadRequest(err).Render(w, r)
+			return
+		}
+
+		todos, err := Todos.List(r.Context(), ownerID)
+		if err != nil {
+			httperr.ErrInternal(err).Render(w, r)
+			return
+		}
+
+		page, total := applyListQuery(todos, lq)
+
+		// lq.limit is -1 when the caller didn't pass ?limit=, meaning no
+		// cap was applied; leave Limit nil rather than reporting a 0 that
+		// would misrepresent an unbounded result as a zero-size page.
+		var limit *int
+		if lq.limit >= 0 {
+			limit = &lq.limit
+		}
+
+		if total == 0 {
 			err := ReturnJSON(w, http.StatusOK, JsonResponse{
 				Success: true,
-				Todos:   Todos,
+				Message: "You have no todos. Try adding one.",
+				Total:   &total,
+				Limit:   limit,
+				Offset:  &lq.offset,
 			})
-
-			// If there is an error with encoding and sending the JSON back to the client, return the error
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				httperr.ErrInternal(err).Render(w, r)
 			}
 			return
 		}
-	})
 
-	r.Post("/create/todo", func(w http.ResponseWriter, r *http.Request) {
+		err = ReturnJSON(w, http.StatusOK, JsonResponse{
+			Success: true,
+			Todos:   page,
+			Total:   &total,
+			Limit:   limit,
+			Offset:  &lq.offset,
+		})
+		if err != nil {
+			httperr.ErrInternal(err).Render(w, r)
+		}
+	}
+}
 
-		var body TodoStruct
+// decodeJSON decodes r's body into dst, rejecting unknown fields. On
+// failure it renders a 400 and reports false so the caller can return early.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		httperr.ErrDecodeFailed(err).Render(w, r)
+		return false
+	}
+	return true
+}
+
+// validateBody runs the shared validator over body. On failure it renders a
+// 422 with the translated field errors through httperr.ErrValidation and
+// reports false so the caller can return early.
+func validateBody(w http.ResponseWriter, r *http.Request, body any) bool {
+	if err := validate.Struct(body); err != nil {
+		httperr.ErrValidation(translateValidationErrors(err)).Render(w, r)
+		return false
+	}
+	return true
+}
+
+// todoIDFromPath extracts and parses the {ID} chi path parameter. On
+// failure it renders a 400 and reports false so the caller can return early.
+func todoIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "ID"))
+	if err != nil {
+		httperr.ErrInvalidTodoID(err).Render(w, r)
+		return 0, false
+	}
+	return id, true
+}
+
+// renderTodoStoreErr renders store.ErrNotFound / store.ErrForbidden as the
+// matching httperr response and reports whether err was one of them, so
+// callers can fall through to their own ErrInternal handling otherwise.
+func renderTodoStoreErr(w http.ResponseWriter, r *http.Request, err error) bool {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		httperr.ErrNotFound("We could not find any todo with this id, double check and try again.").Render(w, r)
+		return true
+	case errors.Is(err, store.ErrForbidden):
+		httperr.ErrForbidden("This todo belongs to another user.").Render(w, r)
+		return true
+	default:
+		return false
+	}
+}
+
+func main() {
+	backend := os.Getenv("STORAGE")
+	flagBackend := flag.String("storage", backend, "storage backend to use: memory or ent")
+	flag.Parse()
+
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		fmt.Println("Error: JWT_SECRET environment variable must be set")
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	Todos, Users, err := newStore(ctx, *flagBackend)
+	if err != nil {
+		fmt.Println("Error setting up storage:", err)
+		os.Exit(1)
+	}
+
+	// r is a new chi router that will handle the HTTP routes.
+	r := chi.NewRouter()
+
+	// Use the Chi middleware Logger to log details about each incoming HTTP request.
+	// This middleware captures and logs the request information, such as HTTP method, path, and duration.
+	// It helps in debugging and monitoring the server's behavior without breaking the server itself.
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.Logger)
+
+	r.Post("/auth/register", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}
 
-		// Create a new JSON decoder for the HTTP request body and disallow unknown
-		// fields.
 		dec := json.NewDecoder(r.Body)
 		dec.DisallowUnknownFields()
+		if err := dec.Decode(&body); err != nil || body.Email == "" || body.Password == "" {
+			httperr.ErrDecodeFailed(err).Render(w, r)
+			return
+		}
 
-		// Decode the HTTP request body into the 'body' struct.
-		err := dec.Decode(&body)
-
-		// Check for any decoding errors.
+		hash, err := auth.HashPassword(body.Password)
 		if err != nil {
-			// If there's an error, return a Bad Request response with an error message.
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "Invalid request data. Please ensure your request is properly formatted.",
-			})
+			httperr.ErrInternal(err).Render(w, r)
+			return
+		}
 
-			// If there is an error with encoding and sending the JSON back to the client, return the error
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		user, err := Users.CreateUser(r.Context(), body.Email, hash)
+		if errors.Is(err, store.ErrUserExists) {
+			httperr.ErrBadRequest(errors.New("an account with this email already exists")).Render(w, r)
+			return
+		}
+		if err != nil {
+			httperr.ErrInternal(err).Render(w, r)
 			return
 		}
 
-		// add the new todo to the slice
-		Todos = append(Todos, TodoStruct{ID: len(Todos) + 1, Title: body.Title, Description: body.Description, Complete: body.Complete})
+		token, err := auth.NewToken([]byte(secret), user.ID, tokenTTL)
+		if err != nil {
+			httperr.ErrInternal(err).Render(w, r)
+			return
+		}
 
-		err = ReturnJSON(w, http.StatusBadRequest, JsonResponse{
+		err = ReturnJSON(w, http.StatusOK, JsonResponse{
 			Success: true,
-			Message: "Todo was created successfully.",
+			Message: token,
 		})
-
-		// If there is an error with encoding and sending the JSON back to the client, return the error
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			httperr.ErrInternal(err).Render(w, r)
 		}
-		return
 	})
 
-	r.Put("/update/todo/{ID}", func(w http.ResponseWriter, r *http.Request) {
-
-		var body TodoStruct
+	r.Post("/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}
 
-		// Create a new JSON decoder for the HTTP request body and disallow unknown
-		// fields.
 		dec := json.NewDecoder(r.Body)
 		dec.DisallowUnknownFields()
+		if err := dec.Decode(&body); err != nil {
+			httperr.ErrDecodeFailed(err).Render(w, r)
+			return
+		}
 
-		// Decode the HTTP request body into the 'body' struct.
-		err := dec.Decode(&body)
+		user, err := Users.GetUserByEmail(r.Context(), body.Email)
 
-		// Check for any decoding errors.
-		if err != nil {
-			// If there's an error, return a Bad Request response with an error message.
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "Invalid request data. Please ensure your request is properly formatted.",
-			})
+		// Always bcrypt-compare, even when the user doesn't exist, so an
+		// unknown email takes the same time to reject as a wrong password
+		// instead of leaking which one it was through response latency.
+		hash := auth.DummyHash
+		if err == nil {
+			hash = user.PasswordHash
+		}
+		passwordErr := auth.CheckPassword(hash, body.Password)
 
-			// If there is an error with encoding and sending the JSON back to the client, return the error
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		if errors.Is(err, store.ErrUserNotFound) || (err == nil && passwordErr != nil) {
+			httperr.ErrUnauthorized("Invalid email or password.").Render(w, r)
 			return
 		}
-
-		// grab the id from the url path and then convert it to an int and handle any errors
-		ID := chi.URLParam(r, "ID")
-		IDint, err := strconv.Atoi(ID)
 		if err != nil {
-			// If there's an error, return an error message.
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "There was a problem with the todo id, please fix it then try again.",
-			})
+			httperr.ErrInternal(err).Render(w, r)
+			return
+		}
 
-			// If there is an error with encoding and sending the JSON back to the client, return the error
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
+		token, err := auth.NewToken([]byte(secret), user.ID, tokenTTL)
+		if err != nil {
+			httperr.ErrInternal(err).Render(w, r)
 			return
 		}
 
-		var found bool
+		err = ReturnJSON(w, http.StatusOK, JsonResponse{
+			Success: true,
+			Message: token,
+		})
+		if err != nil {
+			httperr.ErrInternal(err).Render(w, r)
+		}
+	})
+
+	// Every route below requires a valid bearer token, and is scoped to the
+	// authenticated user's own todos.
+	r.Group(func(r chi.Router) {
+		r.Use(auth.AuthRequired([]byte(secret)))
 
-		// Iterate over the Todos to find the one with the matching ID.
-		for i, k := range Todos {
-			if k.ID == IDint {
-				found = true
+		r.Get("/todos", listTodosHandler(Todos, ""))
+		r.Get("/todos/complete", listTodosHandler(Todos, "complete"))
 
-				// Update the title if it's different from the existing value and if the title in the body is non-empty.
-				if k.Title != body.Title && body.Title != "" {
-					Todos[i].Title = body.Title
-				}
+		r.Post("/create/todo", func(w http.ResponseWriter, r *http.Request) {
+			ownerID, _ := auth.UserID(r.Context())
 
-				// Update the description if it's different from the existing value or if it's explicitly an empty string.
-				if k.Description != body.Description || body.Description == "" {
-					Todos[i].Description = body.Description
-				}
+			var body store.Todo
+			if !decodeJSON(w, r, &body) || !validateBody(w, r, body) {
+				return
+			}
 
-				// Respond with a success status code indicating that the todo was updated.
-				w.WriteHeader(http.StatusNoContent)
-				break
+			// add the new todo to the store
+			_, err := Todos.Create(r.Context(), store.Todo{OwnerID: ownerID, Title: body.Title, Description: body.Description, Complete: body.Complete})
+			if err != nil {
+				httperr.ErrInternal(err).Render(w, r)
+				return
 			}
-		}
 
-		// Check if a matching ID was not found.
-		if !found {
-			// return some json with the message telling the user we couldn't find the todo
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "We could not find any todo with this id, double check and try again.",
+			err = ReturnJSON(w, http.StatusOK, JsonResponse{
+				Success: true,
+				Message: "Todo was created successfully.",
 			})
-
-			// If there is an error with encoding and sending the JSON back to the client, return the error
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				httperr.ErrInternal(err).Render(w, r)
+			}
+		})
+
+		r.Put("/update/todo/{ID}", func(w http.ResponseWriter, r *http.Request) {
+			ownerID, _ := auth.UserID(r.Context())
+
+			var body store.Todo
+			if !decodeJSON(w, r, &body) || !validateBody(w, r, body) {
 				return
 			}
-			return
-		}
-	})
 
-	r.Patch("/complete/{ID}", func(w http.ResponseWriter, r *http.Request) {
+			IDint, ok := todoIDFromPath(w, r)
+			if !ok {
+				return
+			}
 
-		// grab the id from the url path and then convert it to an int and handle any errors
-		ID := chi.URLParam(r, "ID")
-		IDint, err := strconv.Atoi(ID)
-		if err != nil {
-			// If there's an error, return an error message.
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "There was a problem with the todo id, please fix it then try again.",
+			// PUT is a full replace, so every field travels even though
+			// Update's DTO is pointer-based for PATCH's benefit.
+			_, err := Todos.Update(r.Context(), IDint, ownerID, store.UpdateTodoRequest{
+				Title:       &body.Title,
+				Description: &body.Description,
+				Complete:    &body.Complete,
 			})
-
-			// If there is an error with encoding and sending the JSON back to the client, return the error
+			if renderTodoStoreErr(w, r, err) {
+				return
+			}
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				httperr.ErrInternal(err).Render(w, r)
+				return
 			}
-			return
-		}
 
-		var found bool
+			// Respond with a success status code indicating that the todo was updated.
+			w.WriteHeader(http.StatusNoContent)
+		})
 
-		// Iterate over the Todos to find the one with the matching ID.
-		for i, k := range Todos {
-			if k.ID == IDint {
-				found = true
+		r.Patch("/todos/{ID}", func(w http.ResponseWriter, r *http.Request) {
+			ownerID, _ := auth.UserID(r.Context())
 
-				Todos[i].Complete = true
+			// Unlike PUT, any subset of fields may be present; omitted
+			// fields are nil and left unchanged by the store.
+			var body store.UpdateTodoRequest
+			if !decodeJSON(w, r, &body) || !validateBody(w, r, body) {
+				return
+			}
 
-				w.WriteHeader(http.StatusNoContent)
-				break
+			if body.Title == nil && body.Description == nil && body.Complete == nil {
+				httperr.ErrBadRequest(errors.New("request body must set at least one of title, description, or complete")).Render(w, r)
+				return
 			}
-		}
 
-		// Check if a matching ID was not found.
-		if !found {
-			// return some json with the message telling the user we couldn't find the todo
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "We could not find any todo with this id, double check and try again.",
-			})
+			IDint, ok := todoIDFromPath(w, r)
+			if !ok {
+				return
+			}
 
-			// If there is an error with encoding and sending the JSON back to the client, return the error
+			_, err := Todos.Update(r.Context(), IDint, ownerID, body)
+			if renderTodoStoreErr(w, r, err) {
+				return
+			}
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				httperr.ErrInternal(err).Render(w, r)
 				return
 			}
-			return
-		}
 
-	})
+			// Respond with a success status code indicating that the todo was updated.
+			w.WriteHeader(http.StatusNoContent)
+		})
 
-	r.Delete("/delete/{ID}", func(w http.ResponseWriter, r *http.Request) {
-		// Extract the ID from the URL parameters.
-		ID := chi.URLParam(r, "ID")
+		r.Patch("/complete/{ID}", func(w http.ResponseWriter, r *http.Request) {
+			ownerID, _ := auth.UserID(r.Context())
 
-		// Convert the ID to an integer.
-		IDint, err := strconv.Atoi(ID)
-		if err != nil {
-			// If there's an error, return an error message.
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "There was a problem with the todo ID, please fix it and try again.",
-			})
+			IDint, ok := todoIDFromPath(w, r)
+			if !ok {
+				return
+			}
 
-			// If there is an error with encoding and sending the JSON back to the client, return the error.
+			_, err := Todos.Complete(r.Context(), IDint, ownerID)
+			if renderTodoStoreErr(w, r, err) {
+				return
+			}
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				httperr.ErrInternal(err).Render(w, r)
+				return
 			}
-			return
-		}
 
-		var found bool
-
-		// Iterate over the Todos to find the one with the matching ID.
-		for i, k := range Todos {
-			if k.ID == IDint {
-				found = true
+			w.WriteHeader(http.StatusNoContent)
+		})
 
-				// Remove the todo from the Todos slice.
-				Todos = append(Todos[:i], Todos[i+1:]...)
+		r.Delete("/delete/{ID}", func(w http.ResponseWriter, r *http.Request) {
+			ownerID, _ := auth.UserID(r.Context())
 
-				// Respond with a success status code indicating that the todo was deleted.
-				w.WriteHeader(http.StatusNoContent)
-				break
+			IDint, ok := todoIDFromPath(w, r)
+			if !ok {
+				return
 			}
-		}
-
-		// Check if a matching ID was not found.
-		if !found {
-			// Return JSON with the message telling the user that no matching todo was found.
-			err := ReturnJSON(w, http.StatusBadRequest, JsonResponse{
-				Success:      false,
-				ErrorMessage: "We could not find any todo with this ID, double-check and try again.",
-			})
 
-			// If there is an error with encoding and sending the JSON back to the client, return the error.
+			err := Todos.Delete(r.Context(), IDint, ownerID)
+			if renderTodoStoreErr(w, r, err) {
+				return
+			}
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				httperr.ErrInternal(err).Render(w, r)
 				return
 			}
-		}
+
+			// Respond with a success status code indicating that the todo was deleted.
+			w.WriteHeader(http.StatusNoContent)
+		})
 	})
 
 	// Create a new HTTP server with the provided router
@@ -336,12 +581,15 @@ func main() {
 
 	fmt.Println("Shutting down server...")
 
-	// Create a context with a timeout to allow in-flight requests to finish
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// Give in-flight requests up to 10s to finish before the server forces
+	// them closed. Each handler runs under r.Context(), not the ctx created
+	// in main() above (that one only guards newStore's one-time startup
+	// migration), so Shutdown itself is what bounds how long we wait here.
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
 
 	// Shutdown the server gracefully
-	if err := server.Shutdown(ctx); err != nil {
+	if err := server.Shutdown(shutdownCtx); err != nil {
 		fmt.Println("Error during server shutdown:", err)
 	}
 
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+
+	"github.com/vertionn/Todo-API/ent"
+	"github.com/vertionn/Todo-API/ent/todo"
+)
+
+// EntStore is a Store implementation backed by ent. It's driver-agnostic at
+// this layer — the caller is responsible for opening the *ent.Client with
+// whichever driver/DSN it wants — but main.go's "ent" backend currently
+// only ever constructs one with the sqlite3 driver.
+type EntStore struct {
+	client *ent.Client
+}
+
+// NewEntStore wraps client in a Store, running the ent schema migration
+// before returning so the caller doesn't need to remember to do it.
+func NewEntStore(ctx context.Context, client *ent.Client) (*EntStore, error) {
+	if err := client.Schema.Create(ctx); err != nil {
+		return nil, err
+	}
+	return &EntStore{client: client}, nil
+}
+
+func toTodo(t *ent.Todo) Todo {
+	return Todo{
+		ID:          t.ID,
+		OwnerID:     t.OwnerID,
+		Title:       t.Title,
+		Description: t.Description,
+		Complete:    t.Complete,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+func (s *EntStore) List(ctx context.Context, ownerID int) ([]Todo, error) {
+	rows, err := s.client.Todo.Query().Where(todo.OwnerID(ownerID)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todos := make([]Todo, len(rows))
+	for i, row := range rows {
+		todos[i] = toTodo(row)
+	}
+	return todos, nil
+}
+
+func (s *EntStore) Get(ctx context.Context, id, ownerID int) (Todo, error) {
+	row, err := s.client.Todo.Query().Where(todo.ID(id)).Only(ctx)
+	if ent.IsNotFound(err) {
+		return Todo{}, ErrNotFound
+	}
+	if err != nil {
+		return Todo{}, err
+	}
+	if row.OwnerID != ownerID {
+		return Todo{}, ErrForbidden
+	}
+	return toTodo(row), nil
+}
+
+func (s *EntStore) Create(ctx context.Context, in Todo) (Todo, error) {
+	row, err := s.client.Todo.Create().
+		SetOwnerID(in.OwnerID).
+		SetTitle(in.Title).
+		SetDescription(in.Description).
+		SetComplete(in.Complete).
+		Save(ctx)
+	if err != nil {
+		return Todo{}, err
+	}
+	return toTodo(row), nil
+}
+
+// notFoundOrForbidden distinguishes "doesn't exist" from "not yours" after
+// an owner-scoped write matched zero rows, so callers only pay for the
+// extra lookup on the error path instead of on every write.
+func (s *EntStore) notFoundOrForbidden(ctx context.Context, id, ownerID int) error {
+	if _, err := s.Get(ctx, id, ownerID); err != nil {
+		return err
+	}
+	// Get succeeded, so a row with this ID owned by ownerID exists now even
+	// though the write matched none: it appeared between the two queries.
+	// Report ErrNotFound, as the write saw it.
+	return ErrNotFound
+}
+
+func (s *EntStore) Update(ctx context.Context, id, ownerID int, req UpdateTodoRequest) (Todo, error) {
+	if req.Title == nil && req.Description == nil && req.Complete == nil {
+		// Nothing to change, so skip the write (which would only bump
+		// updated_at) and just confirm the todo exists and is ownerID's.
+		return s.Get(ctx, id, ownerID)
+	}
+
+	upd := s.client.Todo.Update().Where(todo.ID(id), todo.OwnerID(ownerID))
+	if req.Title != nil {
+		upd = upd.SetTitle(*req.Title)
+	}
+	if req.Description != nil {
+		upd = upd.SetDescription(*req.Description)
+	}
+	if req.Complete != nil {
+		upd = upd.SetComplete(*req.Complete)
+	}
+
+	n, err := upd.Save(ctx)
+	if err != nil {
+		return Todo{}, err
+	}
+	if n == 0 {
+		return Todo{}, s.notFoundOrForbidden(ctx, id, ownerID)
+	}
+	return s.Get(ctx, id, ownerID)
+}
+
+func (s *EntStore) Complete(ctx context.Context, id, ownerID int) (Todo, error) {
+	n, err := s.client.Todo.Update().
+		Where(todo.ID(id), todo.OwnerID(ownerID)).
+		SetComplete(true).
+		Save(ctx)
+	if err != nil {
+		return Todo{}, err
+	}
+	if n == 0 {
+		return Todo{}, s.notFoundOrForbidden(ctx, id, ownerID)
+	}
+	return s.Get(ctx, id, ownerID)
+}
+
+func (s *EntStore) Delete(ctx context.Context, id, ownerID int) error {
+	n, err := s.client.Todo.Delete().Where(todo.ID(id), todo.OwnerID(ownerID)).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return s.notFoundOrForbidden(ctx, id, ownerID)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+
+	"github.com/vertionn/Todo-API/ent"
+	"github.com/vertionn/Todo-API/ent/user"
+)
+
+// EntUserStore is a UserStore implementation backed by ent.
+type EntUserStore struct {
+	client *ent.Client
+}
+
+// NewEntUserStore wraps client in a UserStore.
+func NewEntUserStore(client *ent.Client) *EntUserStore {
+	return &EntUserStore{client: client}
+}
+
+func toUser(u *ent.User) User {
+	return User{
+		ID:           u.ID,
+		Email:        u.Email,
+		PasswordHash: u.PasswordHash,
+		CreatedAt:    u.CreatedAt,
+	}
+}
+
+func (s *EntUserStore) CreateUser(ctx context.Context, email, passwordHash string) (User, error) {
+	row, err := s.client.User.Create().
+		SetEmail(email).
+		SetPasswordHash(passwordHash).
+		Save(ctx)
+	if ent.IsConstraintError(err) {
+		return User{}, ErrUserExists
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return toUser(row), nil
+}
+
+func (s *EntUserStore) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row, err := s.client.User.Query().Where(user.Email(email)).Only(ctx)
+	if ent.IsNotFound(err) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return toUser(row), nil
+}
+
+func (s *EntUserStore) GetUserByID(ctx context.Context, id int) (User, error) {
+	row, err := s.client.User.Get(ctx, id)
+	if ent.IsNotFound(err) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return toUser(row), nil
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-memory Store implementation guarded by a
+// sync.RWMutex. It's the default backend and is handy for local development
+// and tests, but state is lost on restart.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	todos  []Todo
+	nextID int
+}
+
+// NewMemoryStore returns an empty MemoryStore ready to use.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{nextID: 1}
+}
+
+func (s *MemoryStore) List(ctx context.Context, ownerID int) ([]Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	todos := make([]Todo, 0, len(s.todos))
+	for _, t := range s.todos {
+		if t.OwnerID == ownerID {
+			todos = append(todos, t)
+		}
+	}
+	return todos, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id, ownerID int) (Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.todos {
+		if t.ID == id {
+			if t.OwnerID != ownerID {
+				return Todo{}, ErrForbidden
+			}
+			return t, nil
+		}
+	}
+	return Todo{}, ErrNotFound
+}
+
+func (s *MemoryStore) Create(ctx context.Context, todo Todo) (Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	todo.ID = s.nextID
+	todo.CreatedAt = now
+	todo.UpdatedAt = now
+	s.nextID++
+
+	s.todos = append(s.todos, todo)
+	return todo, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, id, ownerID int, req UpdateTodoRequest) (Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.todos {
+		if t.ID == id {
+			if t.OwnerID != ownerID {
+				return Todo{}, ErrForbidden
+			}
+			if req.Title != nil {
+				s.todos[i].Title = *req.Title
+			}
+			if req.Description != nil {
+				s.todos[i].Description = *req.Description
+			}
+			if req.Complete != nil {
+				s.todos[i].Complete = *req.Complete
+			}
+			s.todos[i].UpdatedAt = time.Now()
+			return s.todos[i], nil
+		}
+	}
+	return Todo{}, ErrNotFound
+}
+
+func (s *MemoryStore) Complete(ctx context.Context, id, ownerID int) (Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.todos {
+		if t.ID == id {
+			if t.OwnerID != ownerID {
+				return Todo{}, ErrForbidden
+			}
+			s.todos[i].Complete = true
+			s.todos[i].UpdatedAt = time.Now()
+			return s.todos[i], nil
+		}
+	}
+	return Todo{}, ErrNotFound
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id, ownerID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.todos {
+		if t.ID == id {
+			if t.OwnerID != ownerID {
+				return ErrForbidden
+			}
+			s.todos = append(s.todos[:i], s.todos[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryUserStore is an in-memory UserStore implementation guarded by a
+// sync.RWMutex. Like MemoryStore, state is lost on restart.
+type MemoryUserStore struct {
+	mu     sync.RWMutex
+	users  []User
+	nextID int
+}
+
+// NewMemoryUserStore returns an empty MemoryUserStore ready to use.
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{nextID: 1}
+}
+
+func (s *MemoryUserStore) CreateUser(ctx context.Context, email, passwordHash string) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		if u.Email == email {
+			return User{}, ErrUserExists
+		}
+	}
+
+	user := User{
+		ID:           s.nextID,
+		Email:        email,
+		PasswordHash: passwordHash,
+		CreatedAt:    time.Now(),
+	}
+	s.nextID++
+
+	s.users = append(s.users, user)
+	return user, nil
+}
+
+func (s *MemoryUserStore) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
+
+func (s *MemoryUserStore) GetUserByID(ctx context.Context, id int) (User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
@@ -0,0 +1,80 @@
+/*
+
+	store.go - Storage abstraction for the Todo API.
+
+	This file defines the Todo domain model and the Store interface that every
+	storage backend (in-memory, ent-backed) must satisfy. Handlers in main.go
+	talk to a Store instead of touching persistence details directly, which
+	lets the backend be swapped with the '-storage' flag without touching any
+	handler code.
+
+*/
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by a Store when no todo matches the given ID at
+// all.
+var ErrNotFound = errors.New("todo not found")
+
+// ErrForbidden is returned by a Store when a todo with the given ID exists
+// but belongs to a different owner.
+var ErrForbidden = errors.New("todo belongs to a different owner")
+
+// Todo represents a single todo item as persisted by a Store.
+type Todo struct {
+	ID          int       `json:"id"`
+	OwnerID     int       `json:"owner_id"`
+	Title       string    `json:"title" validate:"required,min=1,max=120"`
+	Description string    `json:"description" validate:"max=2000"`
+	Complete    bool      `json:"complete"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UpdateTodoRequest carries the fields to change on an existing todo. A nil
+// pointer means "leave this field unchanged", so it doubles as the PUT
+// full-replace payload (every pointer set) and the PATCH partial-update
+// payload (only the pointers the caller sent).
+type UpdateTodoRequest struct {
+	Title       *string `json:"title,omitempty" validate:"omitempty,min=1,max=120"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Complete    *bool   `json:"complete,omitempty"`
+}
+
+// Store is implemented by every Todo storage backend. All methods take a
+// context.Context so callers can cancel or time out in-flight operations,
+// e.g. when the server is shutting down, plus the ownerID of the
+// authenticated user so every backend enforces the same per-user scoping.
+type Store interface {
+	// List returns every todo owned by ownerID.
+	List(ctx context.Context, ownerID int) ([]Todo, error)
+
+	// Get returns the todo with the given ID owned by ownerID, or
+	// ErrNotFound if no todo has that ID, or ErrForbidden if it exists but
+	// is owned by someone else.
+	Get(ctx context.Context, id, ownerID int) (Todo, error)
+
+	// Create persists a new todo and returns it with its assigned ID and
+	// timestamps populated. todo.OwnerID must already be set.
+	Create(ctx context.Context, todo Todo) (Todo, error)
+
+	// Update applies the fields set in req to the todo with the given ID
+	// owned by ownerID, and returns the updated todo, or ErrNotFound /
+	// ErrForbidden as described on Get. Fields left nil in req are left
+	// unchanged.
+	Update(ctx context.Context, id, ownerID int, req UpdateTodoRequest) (Todo, error)
+
+	// Complete marks the todo with the given ID owned by ownerID as
+	// complete and returns it, or ErrNotFound / ErrForbidden as described
+	// on Get.
+	Complete(ctx context.Context, id, ownerID int) (Todo, error)
+
+	// Delete removes the todo with the given ID owned by ownerID, or
+	// returns ErrNotFound / ErrForbidden as described on Get.
+	Delete(ctx context.Context, id, ownerID int) error
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrUserExists is returned by a UserStore when registering an email that's
+// already taken.
+var ErrUserExists = errors.New("user already exists")
+
+// ErrUserNotFound is returned by a UserStore when no user matches the given
+// email or ID.
+var ErrUserNotFound = errors.New("user not found")
+
+// User represents a registered account as persisted by a UserStore.
+type User struct {
+	ID           int       `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// UserStore is implemented by every user storage backend.
+type UserStore interface {
+	// CreateUser persists a new user with the given email and bcrypt
+	// password hash, or returns ErrUserExists if the email is taken.
+	CreateUser(ctx context.Context, email, passwordHash string) (User, error)
+
+	// GetUserByEmail returns the user with the given email, or
+	// ErrUserNotFound.
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+
+	// GetUserByID returns the user with the given ID, or ErrUserNotFound.
+	GetUserByID(ctx context.Context, id int) (User, error)
+}
@@ -0,0 +1,41 @@
+/*
+   validate.go - Request body validation for the Todo API.
+
+   Wraps go-playground/validator so handlers can enforce the struct tags on
+   store.Todo (and friends) and turn the resulting validator.ValidationErrors
+   into the httperr.ValidationError slice rendered via httperr.ErrValidation.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/vertionn/Todo-API/httperr"
+)
+
+// validate is a shared validator instance; it's safe for concurrent use.
+var validate = validator.New()
+
+// translateValidationErrors converts the validator.ValidationErrors in err
+// into the httperr.ValidationError slice sent back via httperr.ErrValidation.
+// It returns nil if err isn't a validator.ValidationErrors.
+func translateValidationErrors(err error) []httperr.ValidationError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+
+	out := make([]httperr.ValidationError, 0, len(verrs))
+	for _, fe := range verrs {
+		out = append(out, httperr.ValidationError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: fmt.Sprintf("%s failed the '%s' validation rule", fe.Field(), fe.Tag()),
+		})
+	}
+	return out
+}